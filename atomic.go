@@ -0,0 +1,131 @@
+package paths
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// AtomicWriter writes to a temp file next to the destination Path and
+// only makes the write visible on Close, via rename. It's returned by
+// Path.OpenAtomicWriter.
+type AtomicWriter struct {
+	dst    *Path
+	tmp    *Path
+	file   io.WriteCloser
+	closed bool
+
+	hasPerm bool
+	perm    os.FileMode
+}
+
+// OpenAtomicWriter returns a writer that buffers its output in a sibling
+// temp file (".<name>.tmp.<random>") in the same directory as the current
+// Path. Close flushes and syncs the temp file, renames it over the
+// destination, and fsyncs the containing directory so the rename is
+// durable even across a crash. Cancel discards the temp file instead of
+// committing it.
+func (p *Path) OpenAtomicWriter() (*AtomicWriter, error) {
+	suffix, err := randomSuffix()
+	if err != nil {
+		return nil, err
+	}
+
+	tmp := p.Parent().Join(fmt.Sprintf(".%s.tmp.%s", p.Base(), suffix))
+
+	file, err := p.fs.Create(tmp.path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AtomicWriter{dst: p, tmp: tmp, file: file}, nil
+}
+
+// Write implements io.Writer.
+func (w *AtomicWriter) Write(p []byte) (int, error) {
+	return w.file.Write(p)
+}
+
+// Chmod records the mode to apply to the destination once the write is
+// committed. Some FS backends (MemFS among them) don't materialize the
+// temp file until Close, so the mode can't be set on it beforehand; it's
+// applied to dst itself as part of Close instead.
+func (w *AtomicWriter) Chmod(perm os.FileMode) {
+	w.hasPerm = true
+	w.perm = perm
+}
+
+// Close commits the write: it syncs the temp file, renames it over the
+// destination, applies any mode recorded via Chmod and fsyncs the
+// containing directory.
+func (w *AtomicWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if syncer, ok := w.file.(interface{ Sync() error }); ok {
+		if err := syncer.Sync(); err != nil {
+			w.file.Close()
+			return err
+		}
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	if err := w.dst.fs.Rename(w.tmp.path, w.dst.path); err != nil {
+		return err
+	}
+
+	if w.hasPerm {
+		if err := w.dst.fs.Chmod(w.dst.path, w.perm); err != nil {
+			return err
+		}
+	}
+
+	return w.dst.fs.SyncDir(w.dst.Parent().path)
+}
+
+// Cancel discards the write, removing the temp file without touching the
+// destination. It's a no-op once Close has already committed the write.
+func (w *AtomicWriter) Cancel() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	w.file.Close()
+	return w.dst.fs.Remove(w.tmp.path)
+}
+
+func randomSuffix() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// WriteFileAtomic writes data to the current Path the same way WriteFile
+// does, but atomically: the new content is only made visible once it has
+// been fully written and synced to stable storage, via OpenAtomicWriter,
+// so readers never observe a partially written file and a crash mid-write
+// leaves the previous content (or nothing) in place, never a truncated one.
+func (p *Path) WriteFileAtomic(data []byte, perm os.FileMode) error {
+	w, err := p.OpenAtomicWriter()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		w.Cancel()
+		return err
+	}
+
+	w.Chmod(perm)
+
+	return w.Close()
+}
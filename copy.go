@@ -0,0 +1,182 @@
+package paths
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// CopyTo copies the contents of the file named src to the file named
+// by dst. The file will be created if it does not already exist. If the
+// destination file exists, all it's contents will be replaced by the contents
+// of the source file. The file mode will be copied from the source and
+// the copied data is synced/flushed to stable storage.
+//
+// If the current Path is a directory, CopyTo recursively copies the whole
+// tree, equivalent to calling CopyDirTo.
+func (p *Path) CopyTo(dst *Path) error {
+	return p.copyTo(dst, false)
+}
+
+// CopyToAtomic is like CopyTo, but every regular file is written through
+// OpenAtomicWriter, so a failure or crash partway through never leaves a
+// half-copied destination file behind.
+func (p *Path) CopyToAtomic(dst *Path) error {
+	return p.copyTo(dst, true)
+}
+
+func (p *Path) copyTo(dst *Path, atomic bool) error {
+	info, err := p.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return p.copyTreeTo(dst, map[uint64]string{}, atomic)
+	}
+
+	return p.copyFileTo(dst, info, atomic)
+}
+
+func (p *Path) copyFileTo(dst *Path, info os.FileInfo, atomic bool) error {
+	if atomic {
+		return p.copyFileToAtomic(dst, info)
+	}
+
+	in, err := p.fs.Open(p.path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := dst.fs.Create(dst.path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+
+	if syncer, ok := out.(interface{ Sync() error }); ok {
+		if err := syncer.Sync(); err != nil {
+			return err
+		}
+	}
+
+	return dst.fs.Chmod(dst.path, info.Mode())
+}
+
+func (p *Path) copyFileToAtomic(dst *Path, info os.FileInfo) error {
+	in, err := p.fs.Open(p.path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	w, err := dst.OpenAtomicWriter()
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, in); err != nil {
+		w.Cancel()
+		return err
+	}
+
+	w.Chmod(info.Mode())
+
+	return w.Close()
+}
+
+// CopyDirTo recursively copies the directory tree rooted at the current
+// Path to dst. Directories are recreated with matching mode bits, regular
+// files and symlinks (preserved as symlinks, not followed) are copied, and
+// device/FIFO/socket nodes are recreated where the OS reports them and dst
+// is backed by OSFS.
+//
+// Files that share the same inode (hardlinks) are only copied once: the
+// first occurrence is copied normally and its destination path recorded,
+// every later occurrence is recreated with a hardlink instead, so copying a
+// tree that hardlinks large files stays both fast and correct. On
+// platforms or FS implementations where the inode number isn't available
+// this tracking is skipped and every entry is copied in full.
+func (p *Path) CopyDirTo(dst *Path) error {
+	return p.copyTreeTo(dst, map[uint64]string{}, false)
+}
+
+func (p *Path) copyTreeTo(dst *Path, inodes map[uint64]string, atomic bool) error {
+	info, err := p.fs.Lstat(p.path)
+	if err != nil {
+		return fmt.Errorf("paths: stat %s: %w", p.path, err)
+	}
+
+	if info.IsDir() {
+		if err := p.copyDirTo(dst, info, inodes, atomic); err != nil {
+			return fmt.Errorf("paths: copying directory %s: %w", p.path, err)
+		}
+		return nil
+	}
+
+	if link, ok := lookupInode(info, inodes); ok {
+		if err := dst.fs.Link(link, dst.path); err != nil {
+			return fmt.Errorf("paths: linking %s: %w", dst.path, err)
+		}
+		return nil
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		err = p.copySymlinkTo(dst)
+	case info.Mode()&(os.ModeDevice|os.ModeNamedPipe|os.ModeSocket) != 0:
+		err = p.copySpecialTo(dst, info)
+	default:
+		err = p.copyFileTo(dst, info, atomic)
+	}
+	if err != nil {
+		return fmt.Errorf("paths: copying %s: %w", p.path, err)
+	}
+
+	if info.Mode()&os.ModeSymlink == 0 {
+		if err := dst.Chtimes(info.ModTime(), info.ModTime()); err != nil {
+			return fmt.Errorf("paths: setting mtime on %s: %w", dst.path, err)
+		}
+	}
+
+	recordInode(info, dst.path, inodes)
+	return nil
+}
+
+func (p *Path) copyDirTo(dst *Path, info os.FileInfo, inodes map[uint64]string, atomic bool) error {
+	if err := dst.fs.MkdirAll(dst.path, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	entries, err := p.ReadDir()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := entry.copyTreeTo(dst.Join(entry.Base()), inodes, atomic); err != nil {
+			return err
+		}
+	}
+
+	if err := dst.fs.Chmod(dst.path, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	// Set last, since writing the children above bumps the directory's
+	// own mtime.
+	return dst.Chtimes(info.ModTime(), info.ModTime())
+}
+
+func (p *Path) copySymlinkTo(dst *Path) error {
+	target, err := p.fs.Readlink(p.path)
+	if err != nil {
+		return err
+	}
+	return dst.fs.Symlink(target, dst.path)
+}
@@ -0,0 +1,37 @@
+package paths
+
+import "os"
+
+// DiskUsage recursively sums the size of every file under the current
+// Path, following the semantics of `du -sb`: symlinks are reported but
+// not traversed, and each inode is only counted once so hardlinked files
+// don't inflate the total. On platforms where the inode number isn't
+// available every entry is counted individually.
+func (p *Path) DiskUsage() (int64, error) {
+	var total int64
+	seen := map[uint64]struct{}{}
+
+	err := p.Walk(func(entry *Path, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		if ino, ok := inodeOf(info); ok {
+			if _, counted := seen[ino]; counted {
+				return nil
+			}
+			seen[ino] = struct{}{}
+		}
+
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,128 @@
+package paths
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FS abstracts the filesystem operations Path relies on. The default,
+// returned by New, is OSFS, which operates on the local filesystem; other
+// implementations (MemFS, SubFS) let code built on top of Path be tested,
+// or backed by something other than the local disk, without changing a
+// single call site.
+type FS interface {
+	Stat(name string) (os.FileInfo, error)
+	Lstat(name string) (os.FileInfo, error)
+	Open(name string) (io.ReadCloser, error)
+	Create(name string) (io.WriteCloser, error)
+	MkdirAll(name string, perm os.FileMode) error
+	Remove(name string) error
+	ReadDir(name string) ([]os.FileInfo, error)
+	Chtimes(name string, atime, mtime time.Time) error
+	Chmod(name string, mode os.FileMode) error
+	Symlink(oldname, newname string) error
+	Readlink(name string) (string, error)
+	Link(oldname, newname string) error
+	Rename(oldname, newname string) error
+	SyncDir(name string) error
+}
+
+// OSFS is the FS implementation backed by the local filesystem, used by
+// Path values created through New.
+type OSFS struct{}
+
+// Stat implements FS.
+func (OSFS) Stat(name string) (os.FileInfo, error) { return os.Stat(name) }
+
+// Lstat implements FS.
+func (OSFS) Lstat(name string) (os.FileInfo, error) { return os.Lstat(name) }
+
+// Open implements FS.
+func (OSFS) Open(name string) (io.ReadCloser, error) { return os.Open(name) }
+
+// Create implements FS.
+func (OSFS) Create(name string) (io.WriteCloser, error) { return os.Create(name) }
+
+// MkdirAll implements FS.
+func (OSFS) MkdirAll(name string, perm os.FileMode) error { return os.MkdirAll(name, perm) }
+
+// Remove implements FS.
+func (OSFS) Remove(name string) error { return os.Remove(name) }
+
+// ReadDir implements FS.
+func (OSFS) ReadDir(name string) ([]os.FileInfo, error) { return ioutil.ReadDir(name) }
+
+// Chtimes implements FS.
+func (OSFS) Chtimes(name string, atime, mtime time.Time) error {
+	return os.Chtimes(name, atime, mtime)
+}
+
+// Chmod implements FS.
+func (OSFS) Chmod(name string, mode os.FileMode) error { return os.Chmod(name, mode) }
+
+// Symlink implements FS.
+func (OSFS) Symlink(oldname, newname string) error { return os.Symlink(oldname, newname) }
+
+// Readlink implements FS.
+func (OSFS) Readlink(name string) (string, error) { return os.Readlink(name) }
+
+// Link implements FS.
+func (OSFS) Link(oldname, newname string) error { return os.Link(oldname, newname) }
+
+// Rename implements FS.
+func (OSFS) Rename(oldname, newname string) error { return os.Rename(oldname, newname) }
+
+// SyncDir implements FS by opening name and fsyncing it, so that a
+// preceding rename of one of its entries is made durable across a crash.
+func (OSFS) SyncDir(name string) error {
+	dir, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// symlinkResolver is implemented by FS backends that can resolve an entire
+// path's worth of symlinks in one go, such as OSFS via filepath.EvalSymlinks.
+// Path.FollowSymLink prefers it over the generic, single-target resolution
+// below, so behavior on the local filesystem is unchanged.
+type symlinkResolver interface {
+	resolveSymlinks(path string) (string, error)
+}
+
+func (OSFS) resolveSymlinks(path string) (string, error) {
+	return filepath.EvalSymlinks(path)
+}
+
+// resolveSymlinks resolves path on fs. Backends implementing
+// symlinkResolver resolve it themselves; on any other backend up to 40
+// levels of symlinks are followed, the same limit used by the Linux kernel.
+func resolveSymlinks(fs FS, path string) (string, error) {
+	if r, ok := fs.(symlinkResolver); ok {
+		return r.resolveSymlinks(path)
+	}
+
+	current := path
+	for i := 0; i <= 40; i++ {
+		info, err := fs.Lstat(current)
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return current, nil
+		}
+		target, err := fs.Readlink(current)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(current), target)
+		}
+		current = target
+	}
+	return "", &os.PathError{Op: "followsymlink", Path: path, Err: os.ErrInvalid}
+}
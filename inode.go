@@ -0,0 +1,25 @@
+package paths
+
+import "os"
+
+// lookupInode returns the destination path previously recorded for the
+// inode backing info, if any.
+func lookupInode(info os.FileInfo, inodes map[uint64]string) (string, bool) {
+	ino, ok := inodeOf(info)
+	if !ok {
+		return "", false
+	}
+	dst, ok := inodes[ino]
+	return dst, ok
+}
+
+// recordInode remembers dst as the destination copy for the inode backing
+// info, so later occurrences of the same inode can be hardlinked to it
+// instead of being copied again.
+func recordInode(info os.FileInfo, dst string, inodes map[uint64]string) {
+	ino, ok := inodeOf(info)
+	if !ok {
+		return
+	}
+	inodes[ino] = dst
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly && !solaris
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly,!solaris
+
+package paths
+
+import "os"
+
+// inodeOf reports that inode information is unavailable on this platform,
+// so hardlink-aware copying is skipped.
+func inodeOf(info os.FileInfo) (uint64, bool) {
+	return 0, false
+}
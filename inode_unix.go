@@ -0,0 +1,19 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly || solaris
+// +build linux darwin freebsd netbsd openbsd dragonfly solaris
+
+package paths
+
+import (
+	"os"
+	"syscall"
+)
+
+// inodeOf returns the inode number backing info, read from the
+// platform-specific syscall.Stat_t exposed through os.FileInfo.Sys.
+func inodeOf(info os.FileInfo) (uint64, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(st.Ino), true
+}
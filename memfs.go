@@ -0,0 +1,251 @@
+package paths
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	gopath "path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemFS is an in-memory FS implementation. It is useful for unit-testing
+// code built on top of Path without touching disk: construct it with
+// NewMemFS and hand it to NewOnFS.
+type MemFS struct {
+	mu    sync.Mutex
+	nodes map[string]*memNode
+}
+
+type memNode struct {
+	mode    os.FileMode
+	data    []byte
+	modTime time.Time
+	target  string
+}
+
+// NewMemFS returns an empty MemFS containing just the root directory "/".
+func NewMemFS() *MemFS {
+	return &MemFS{
+		nodes: map[string]*memNode{
+			"/": {mode: os.ModeDir | 0755, modTime: time.Now()},
+		},
+	}
+}
+
+func memClean(name string) string {
+	return gopath.Clean("/" + name)
+}
+
+type memFileInfo struct {
+	name string
+	node *memNode
+}
+
+func (fi *memFileInfo) Name() string       { return fi.name }
+func (fi *memFileInfo) Size() int64        { return int64(len(fi.node.data)) }
+func (fi *memFileInfo) Mode() os.FileMode  { return fi.node.mode }
+func (fi *memFileInfo) ModTime() time.Time { return fi.node.modTime }
+func (fi *memFileInfo) IsDir() bool        { return fi.node.mode.IsDir() }
+func (fi *memFileInfo) Sys() interface{}   { return nil }
+
+// Stat implements FS.
+func (fs *MemFS) Stat(name string) (os.FileInfo, error) {
+	clean, err := resolveSymlinks(fs, name)
+	if err != nil {
+		return nil, err
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.lstat(clean, name)
+}
+
+// Lstat implements FS.
+func (fs *MemFS) Lstat(name string) (os.FileInfo, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.lstat(memClean(name), name)
+}
+
+func (fs *MemFS) lstat(clean, name string) (os.FileInfo, error) {
+	n, ok := fs.nodes[clean]
+	if !ok {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+	return &memFileInfo{name: gopath.Base(clean), node: n}, nil
+}
+
+// Open implements FS.
+func (fs *MemFS) Open(name string) (io.ReadCloser, error) {
+	clean, err := resolveSymlinks(fs, name)
+	if err != nil {
+		return nil, err
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	n, ok := fs.nodes[clean]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	if n.mode.IsDir() {
+		return nil, &os.PathError{Op: "open", Path: name, Err: fmt.Errorf("is a directory")}
+	}
+	return ioutil.NopCloser(bytes.NewReader(n.data)), nil
+}
+
+type memWriter struct {
+	fs   *MemFS
+	name string
+	buf  bytes.Buffer
+}
+
+func (w *memWriter) Write(p []byte) (int, error) { return w.buf.Write(p) }
+
+func (w *memWriter) Close() error {
+	w.fs.mu.Lock()
+	defer w.fs.mu.Unlock()
+	mode := os.FileMode(0644)
+	if n, ok := w.fs.nodes[w.name]; ok {
+		mode = n.mode
+	}
+	w.fs.nodes[w.name] = &memNode{mode: mode, data: w.buf.Bytes(), modTime: time.Now()}
+	return nil
+}
+
+// Create implements FS.
+func (fs *MemFS) Create(name string) (io.WriteCloser, error) {
+	clean := memClean(name)
+	fs.mu.Lock()
+	_, ok := fs.nodes[gopath.Dir(clean)]
+	fs.mu.Unlock()
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return &memWriter{fs: fs, name: clean}, nil
+}
+
+// MkdirAll implements FS.
+func (fs *MemFS) MkdirAll(name string, perm os.FileMode) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for clean := memClean(name); ; clean = gopath.Dir(clean) {
+		if _, ok := fs.nodes[clean]; !ok {
+			fs.nodes[clean] = &memNode{mode: os.ModeDir | perm, modTime: time.Now()}
+		}
+		if clean == "/" {
+			return nil
+		}
+	}
+}
+
+// Remove implements FS.
+func (fs *MemFS) Remove(name string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	clean := memClean(name)
+	if _, ok := fs.nodes[clean]; !ok {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+	for p := range fs.nodes {
+		if p != clean && gopath.Dir(p) == clean {
+			return &os.PathError{Op: "remove", Path: name, Err: fmt.Errorf("directory not empty")}
+		}
+	}
+	delete(fs.nodes, clean)
+	return nil
+}
+
+// ReadDir implements FS.
+func (fs *MemFS) ReadDir(name string) ([]os.FileInfo, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	clean := memClean(name)
+	n, ok := fs.nodes[clean]
+	if !ok || !n.mode.IsDir() {
+		return nil, &os.PathError{Op: "readdir", Path: name, Err: os.ErrNotExist}
+	}
+	var infos []os.FileInfo
+	for p, child := range fs.nodes {
+		if p != clean && gopath.Dir(p) == clean {
+			infos = append(infos, &memFileInfo{name: gopath.Base(p), node: child})
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name() < infos[j].Name() })
+	return infos, nil
+}
+
+// Chtimes implements FS.
+func (fs *MemFS) Chtimes(name string, atime, mtime time.Time) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	n, ok := fs.nodes[memClean(name)]
+	if !ok {
+		return &os.PathError{Op: "chtimes", Path: name, Err: os.ErrNotExist}
+	}
+	n.modTime = mtime
+	return nil
+}
+
+// Chmod implements FS.
+func (fs *MemFS) Chmod(name string, mode os.FileMode) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	n, ok := fs.nodes[memClean(name)]
+	if !ok {
+		return &os.PathError{Op: "chmod", Path: name, Err: os.ErrNotExist}
+	}
+	n.mode = (n.mode & os.ModeType) | (mode & os.ModePerm)
+	return nil
+}
+
+// Symlink implements FS.
+func (fs *MemFS) Symlink(oldname, newname string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.nodes[memClean(newname)] = &memNode{mode: os.ModeSymlink | 0777, target: oldname, modTime: time.Now()}
+	return nil
+}
+
+// Readlink implements FS.
+func (fs *MemFS) Readlink(name string) (string, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	n, ok := fs.nodes[memClean(name)]
+	if !ok || n.mode&os.ModeSymlink == 0 {
+		return "", &os.PathError{Op: "readlink", Path: name, Err: os.ErrInvalid}
+	}
+	return n.target, nil
+}
+
+// Link implements FS.
+func (fs *MemFS) Link(oldname, newname string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	n, ok := fs.nodes[memClean(oldname)]
+	if !ok {
+		return &os.PathError{Op: "link", Path: oldname, Err: os.ErrNotExist}
+	}
+	fs.nodes[memClean(newname)] = n
+	return nil
+}
+
+// Rename implements FS.
+func (fs *MemFS) Rename(oldname, newname string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	oldClean := memClean(oldname)
+	n, ok := fs.nodes[oldClean]
+	if !ok {
+		return &os.PathError{Op: "rename", Path: oldname, Err: os.ErrNotExist}
+	}
+	delete(fs.nodes, oldClean)
+	fs.nodes[memClean(newname)] = n
+	return nil
+}
+
+// SyncDir implements FS. There's no real directory to fsync, so it's a
+// no-op.
+func (fs *MemFS) SyncDir(name string) error { return nil }
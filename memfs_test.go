@@ -0,0 +1,151 @@
+package paths
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMemFSBasics(t *testing.T) {
+	mfs := NewMemFS()
+	root := NewOnFS(mfs, "/")
+	if err := root.MkdirAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := root.Join("dir")
+	if err := dir.MkdirAll(); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	file := dir.Join("f.txt")
+	if err := file.WriteFile([]byte("content")); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	data, err := file.ReadFile()
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "content" {
+		t.Fatalf("ReadFile = %q, want %q", data, "content")
+	}
+
+	entries, err := dir.ReadDir()
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Base() != "f.txt" {
+		t.Fatalf("ReadDir = %v, want [f.txt]", entries)
+	}
+
+	if err := mfs.Chmod(file.path, 0600); err != nil {
+		t.Fatalf("Chmod: %v", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	link := dir.Join("link.txt")
+	if err := mfs.Symlink(file.path, link.path); err != nil {
+		t.Fatalf("Symlink: %v", err)
+	}
+	target, err := mfs.Readlink(link.path)
+	if err != nil {
+		t.Fatalf("Readlink: %v", err)
+	}
+	if target != file.path {
+		t.Fatalf("Readlink = %q, want %q", target, file.path)
+	}
+	linkData, err := link.ReadFile()
+	if err != nil {
+		t.Fatalf("ReadFile through symlink: %v", err)
+	}
+	if string(linkData) != "content" {
+		t.Fatalf("ReadFile through symlink = %q, want %q", linkData, "content")
+	}
+
+	hard := dir.Join("hard.txt")
+	if err := mfs.Link(file.path, hard.path); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+	hardData, err := hard.ReadFile()
+	if err != nil {
+		t.Fatalf("ReadFile of hardlink: %v", err)
+	}
+	if string(hardData) != "content" {
+		t.Fatalf("ReadFile of hardlink = %q, want %q", hardData, "content")
+	}
+
+	renamed := dir.Join("renamed.txt")
+	if err := mfs.Rename(file.path, renamed.path); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if _, err := file.Stat(); !os.IsNotExist(err) {
+		t.Fatalf("Stat on renamed-away path = %v, want IsNotExist", err)
+	}
+	renamedData, err := renamed.ReadFile()
+	if err != nil {
+		t.Fatalf("ReadFile of renamed: %v", err)
+	}
+	if string(renamedData) != "content" {
+		t.Fatalf("ReadFile of renamed = %q, want %q", renamedData, "content")
+	}
+
+	if err := mfs.SyncDir(dir.path); err != nil {
+		t.Fatalf("SyncDir: %v", err)
+	}
+
+	if err := hard.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := hard.Stat(); !os.IsNotExist(err) {
+		t.Fatalf("Stat after Remove = %v, want IsNotExist", err)
+	}
+}
+
+// TestMemFSWriteFileAtomicRoundTrip exercises WriteFileAtomic on MemFS,
+// whose writer only materializes a node on Close: the temp file doesn't
+// exist until then, so anything that tries to touch it beforehand (such
+// as a premature Chmod) would fail here first.
+func TestMemFSWriteFileAtomicRoundTrip(t *testing.T) {
+	mfs := NewMemFS()
+	root := NewOnFS(mfs, "/")
+	if err := root.MkdirAll(); err != nil {
+		t.Fatal(err)
+	}
+	f := root.Join("f.txt")
+
+	if err := f.WriteFileAtomic([]byte("atomic"), 0640); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+
+	data, err := f.ReadFile()
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "atomic" {
+		t.Fatalf("got %q, want %q", data, "atomic")
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Fatalf("mode = %v, want 0640", info.Mode().Perm())
+	}
+
+	entries, err := root.ReadDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Base() != "f.txt" {
+			t.Fatalf("leftover entry after atomic write: %s", e.Base())
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package paths
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// sniffLen is how much of the file MimeType reads before sniffing its
+// content type. It's larger than the 512 bytes http.DetectContentType
+// itself looks at so the additional signatures below, some of which sit
+// further into the file (e.g. tar's "ustar" at offset 257), can be found.
+const sniffLen = 3072
+
+type magicSignature struct {
+	mime  string
+	match func([]byte) bool
+}
+
+var magicSignatures = []magicSignature{
+	{"application/zip", func(b []byte) bool {
+		return hasAnyPrefix(b, []byte("PK\x03\x04"), []byte("PK\x05\x06"), []byte("PK\x07\x08"))
+	}},
+	{"application/gzip", func(b []byte) bool { return bytes.HasPrefix(b, []byte{0x1f, 0x8b}) }},
+	{"application/x-bzip2", func(b []byte) bool { return bytes.HasPrefix(b, []byte("BZh")) }},
+	{"application/x-xz", func(b []byte) bool {
+		return bytes.HasPrefix(b, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00})
+	}},
+	{"application/zstd", func(b []byte) bool { return bytes.HasPrefix(b, []byte{0x28, 0xb5, 0x2f, 0xfd}) }},
+	{"application/x-7z-compressed", func(b []byte) bool {
+		return bytes.HasPrefix(b, []byte{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c})
+	}},
+	{"application/x-tar", isTarMagic},
+	{"application/pdf", func(b []byte) bool { return bytes.HasPrefix(b, []byte("%PDF-")) }},
+	{"application/x-elf", func(b []byte) bool { return bytes.HasPrefix(b, []byte{0x7f, 'E', 'L', 'F'}) }},
+	{"application/x-mach-binary", isMachOMagic},
+	{"application/vnd.microsoft.portable-executable", isPEMagic},
+}
+
+func hasAnyPrefix(b []byte, prefixes ...[]byte) bool {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(b, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTarMagic(b []byte) bool {
+	return len(b) >= 262 && string(b[257:262]) == "ustar"
+}
+
+func isMachOMagic(b []byte) bool {
+	if len(b) < 4 {
+		return false
+	}
+	return hasAnyPrefix(b,
+		[]byte{0xfe, 0xed, 0xfa, 0xce}, // MH_MAGIC
+		[]byte{0xce, 0xfa, 0xed, 0xfe}, // MH_CIGAM
+		[]byte{0xfe, 0xed, 0xfa, 0xcf}, // MH_MAGIC_64
+		[]byte{0xcf, 0xfa, 0xed, 0xfe}, // MH_CIGAM_64
+	)
+}
+
+// isPEMagic recognizes a Windows PE/COFF executable: a classic "MZ" DOS
+// header whose e_lfanew field (a little-endian uint32 at offset 0x3c)
+// points at a "PE\0\0" signature further into the file.
+func isPEMagic(b []byte) bool {
+	if len(b) < 0x40 || b[0] != 'M' || b[1] != 'Z' {
+		return false
+	}
+	off := int(binary.LittleEndian.Uint32(b[0x3c:0x40]))
+	if off < 0 || off+4 > len(b) {
+		return false
+	}
+	return bytes.Equal(b[off:off+4], []byte("PE\x00\x00"))
+}
+
+// MimeType reads the first few KB of the file and returns its content
+// type, detected by magic-number sniffing. It extends the signatures
+// known to http.DetectContentType with common archive and executable
+// formats (zip, gzip, bzip2, xz, zstd, tar, 7z, PDF, ELF, Mach-O, PE)
+// before falling back to http.DetectContentType itself.
+func (p *Path) MimeType() (string, error) {
+	in, err := p.fs.Open(p.path)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(in, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	buf = buf[:n]
+
+	for _, sig := range magicSignatures {
+		if sig.match(buf) {
+			return sig.mime, nil
+		}
+	}
+
+	return http.DetectContentType(buf), nil
+}
+
+// IsText reports whether the file looks like text, based on its MimeType.
+func (p *Path) IsText() (bool, error) {
+	mt, err := p.MimeType()
+	if err != nil {
+		return false, err
+	}
+
+	if strings.HasPrefix(mt, "text/") {
+		return true, nil
+	}
+	switch strings.SplitN(mt, ";", 2)[0] {
+	case "application/json", "application/xml", "application/javascript":
+		return true, nil
+	}
+	return false, nil
+}
@@ -1,7 +1,6 @@
 package paths
 
 import (
-	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -10,18 +9,33 @@ import (
 
 // Path represents a path
 type Path struct {
+	fs                 FS
 	path               string
 	cachedFileInfo     os.FileInfo
 	cachedFileInfoTime time.Time
 }
 
-// New creates a new Path object. If path is the empty string
-// then nil is returned.
+// New creates a new Path object backed by the local filesystem. If path
+// is the empty string then nil is returned.
 func New(path string) *Path {
+	return NewOnFS(OSFS{}, path)
+}
+
+// NewOnFS creates a new Path object whose filesystem operations are
+// performed through fs instead of the local filesystem. If path is the
+// empty string then nil is returned.
+func NewOnFS(fs FS, path string) *Path {
 	if path == "" {
 		return nil
 	}
-	return &Path{path: path}
+	if fs == nil {
+		fs = OSFS{}
+	}
+	return &Path{fs: fs, path: path}
+}
+
+func (p *Path) new(path string) *Path {
+	return NewOnFS(p.fs, path)
 }
 
 func (p *Path) setCachedFileInfo(info os.FileInfo) {
@@ -33,7 +47,7 @@ func (p *Path) setCachedFileInfo(info os.FileInfo) {
 // cached internally for next queries. To ensure that the cached
 // FileInfo entry is updated just call Stat again.
 func (p *Path) Stat() (os.FileInfo, error) {
-	info, err := os.Stat(p.path)
+	info, err := p.fs.Stat(p.path)
 	if err != nil {
 		return nil, err
 	}
@@ -52,12 +66,12 @@ func (p *Path) stat() (os.FileInfo, error) {
 
 // Clone create a copy of the Path object
 func (p *Path) Clone() *Path {
-	return New(p.path)
+	return p.new(p.path)
 }
 
 // Join create a new Path by joining the provided paths
 func (p *Path) Join(paths ...string) *Path {
-	return New(filepath.Join(p.path, filepath.Join(paths...)))
+	return p.new(filepath.Join(p.path, filepath.Join(paths...)))
 }
 
 // JoinPath create a new Path by joining the provided paths
@@ -81,7 +95,7 @@ func (p *Path) RelTo(r *Path) (*Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	return New(rel), nil
+	return p.new(rel), nil
 }
 
 // Abs returns the absolute path of the current Path
@@ -90,7 +104,7 @@ func (p *Path) Abs() (*Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	return New(abs), nil
+	return p.new(abs), nil
 }
 
 // IsAbs returns true if the Path is absolute
@@ -111,30 +125,30 @@ func (p *Path) ToAbs() error {
 // Clean Clean returns the shortest path name equivalent to path by
 // purely lexical processing
 func (p *Path) Clean() *Path {
-	return New(filepath.Clean(p.path))
+	return p.new(filepath.Clean(p.path))
 }
 
 // Parent returns all but the last element of path, typically the path's
 // directory or the parent directory if the path is already a directory
 func (p *Path) Parent() *Path {
-	return New(filepath.Dir(p.path))
+	return p.new(filepath.Dir(p.path))
 }
 
 // MkdirAll creates a directory named path, along with any necessary
 // parents, and returns nil, or else returns an error
 func (p *Path) MkdirAll() error {
-	return os.MkdirAll(p.path, os.FileMode(0755))
+	return p.fs.MkdirAll(p.path, os.FileMode(0755))
 }
 
 // Remove removes the named file or directory
 func (p *Path) Remove() error {
-	return os.Remove(p.path)
+	return p.fs.Remove(p.path)
 }
 
 // FollowSymLink transforms the current path to the path pointed by the
 // symlink if path is a symlink, otherwise it does nothing
 func (p *Path) FollowSymLink() error {
-	resolvedPath, err := filepath.EvalSymlinks(p.path)
+	resolvedPath, err := resolveSymlinks(p.fs, p.path)
 	if err != nil {
 		return err
 	}
@@ -170,7 +184,7 @@ func (p *Path) IsDir() (bool, error) {
 // ReadDir returns a PathList containing the content of the directory
 // pointed by the current Path
 func (p *Path) ReadDir() (PathList, error) {
-	infos, err := ioutil.ReadDir(p.path)
+	infos, err := p.fs.ReadDir(p.path)
 	if err != nil {
 		return nil, err
 	}
@@ -183,63 +197,40 @@ func (p *Path) ReadDir() (PathList, error) {
 	return paths, nil
 }
 
-// CopyTo copies the contents of the file named src to the file named
-// by dst. The file will be created if it does not already exist. If the
-// destination file exists, all it's contents will be replaced by the contents
-// of the source file. The file mode will be copied from the source and
-// the copied data is synced/flushed to stable storage.
-func (p *Path) CopyTo(dst *Path) error {
-	in, err := os.Open(p.path)
-	if err != nil {
-		return err
-	}
-	defer in.Close()
-
-	out, err := os.Create(dst.path)
-	if err != nil {
-		return err
-	}
-	defer out.Close()
-
-	if _, err := io.Copy(out, in); err != nil {
-		return err
-	}
-
-	if err := out.Sync(); err != nil {
-		return err
-	}
-
-	si, err := p.Stat()
-	if err != nil {
-		return err
-	}
-
-	err = os.Chmod(dst.path, si.Mode())
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // Chtimes changes the access and modification times of the named file,
 // similar to the Unix utime() or utimes() functions.
 func (p *Path) Chtimes(atime, mtime time.Time) error {
-	return os.Chtimes(p.path, atime, mtime)
+	return p.fs.Chtimes(p.path, atime, mtime)
 }
 
 // ReadFile reads the file named by filename and returns the contents
 func (p *Path) ReadFile() ([]byte, error) {
-	return ioutil.ReadFile(p.path)
+	in, err := p.fs.Open(p.path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	return ioutil.ReadAll(in)
 }
 
 // WriteFile writes data to a file named by filename. If the file
 // does not exist, WriteFile creates it otherwise WriteFile truncates
 // it before writing.
 func (p *Path) WriteFile(data []byte) error {
-	return ioutil.WriteFile(p.path, data, os.FileMode(0644))
+	out, err := p.fs.Create(p.path)
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write(data); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return p.fs.Chmod(p.path, os.FileMode(0644))
 }
 
 func (p *Path) String() string {
 	return p.path
-}
\ No newline at end of file
+}
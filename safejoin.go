@@ -0,0 +1,149 @@
+package paths
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPathEscape is returned by SafeJoin and SafeJoinPath when the
+// requested path, once symlinks along it are resolved, falls outside of
+// the jail root.
+type ErrPathEscape struct {
+	Root string
+	Path string
+}
+
+func (e *ErrPathEscape) Error() string {
+	return fmt.Sprintf("paths: %s escapes jail root %s", e.Path, e.Root)
+}
+
+// SafeJoin treats the current Path as a jail root and joins elem to it,
+// guaranteeing that the result stays inside the root even if a component
+// along the way is a symlink pointing outside of it. Every component is
+// resolved and re-checked against the root as it's walked, so a symlink
+// can't be used to escape the jail the way it could with a plain Join.
+//
+// Components that don't exist yet are allowed, to support jailed creation
+// of new files and directories; only existing symlinks are resolved.
+func (p *Path) SafeJoin(elem ...string) (*Path, error) {
+	rootAbs, err := p.Abs()
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Clean(rootAbs.path)
+
+	target := filepath.Clean(filepath.Join(append([]string{root}, elem...)...))
+
+	resolved, err := safeResolve(p.fs, root, target)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.new(resolved), nil
+}
+
+// SafeJoinPath is like SafeJoin but takes the elements to join as Paths.
+func (p *Path) SafeJoinPath(elem ...*Path) (*Path, error) {
+	elems := make([]string, len(elem))
+	for i, e := range elem {
+		elems[i] = e.path
+	}
+	return p.SafeJoin(elems...)
+}
+
+// IsInside reports whether the current Path, once symlinks in it are
+// resolved, is contained within root.
+func (p *Path) IsInside(root *Path) (bool, error) {
+	rootAbs, err := root.Abs()
+	if err != nil {
+		return false, err
+	}
+	rootClean := filepath.Clean(rootAbs.path)
+
+	selfAbs, err := p.Abs()
+	if err != nil {
+		return false, err
+	}
+
+	resolved, err := resolveSymlinks(p.fs, selfAbs.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return false, err
+		}
+		resolved = filepath.Clean(selfAbs.path)
+	}
+
+	return withinRoot(rootClean, resolved), nil
+}
+
+// withinRoot reports whether candidate is root itself or lexically
+// beneath it. Both arguments must already be absolute and clean.
+func withinRoot(root, candidate string) bool {
+	rel, err := filepath.Rel(root, candidate)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// safeResolve resolves target, which must be an absolute, clean path
+// beneath root, one component at a time, following and re-validating
+// every symlink found along the way against root.
+func safeResolve(fsys FS, root, target string) (string, error) {
+	if !withinRoot(root, target) {
+		return "", &ErrPathEscape{Root: root, Path: target}
+	}
+
+	rel, err := filepath.Rel(root, target)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." {
+		return root, nil
+	}
+
+	current := root
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		resolved, err := followSymlinkChain(fsys, root, filepath.Join(current, part))
+		if err != nil {
+			return "", err
+		}
+		current = resolved
+	}
+	return current, nil
+}
+
+// followSymlinkChain resolves path's symlink chain, rejecting with
+// ErrPathEscape as soon as a target lands outside of root. A path that
+// doesn't exist, or isn't a symlink, is returned unchanged.
+func followSymlinkChain(fsys FS, root, path string) (string, error) {
+	for i := 0; i < 40; i++ {
+		info, err := fsys.Lstat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return path, nil
+			}
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return path, nil
+		}
+
+		target, err := fsys.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		target = filepath.Clean(target)
+
+		if !withinRoot(root, target) {
+			return "", &ErrPathEscape{Root: root, Path: target}
+		}
+		path = target
+	}
+	return "", &os.PathError{Op: "safejoin", Path: path, Err: os.ErrInvalid}
+}
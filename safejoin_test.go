@@ -0,0 +1,64 @@
+package paths
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeJoin(t *testing.T) {
+	dir, err := os.MkdirTemp("", "safejoin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	jail := filepath.Join(dir, "jail")
+	outside := filepath.Join(dir, "outside")
+	for _, d := range []string{jail, outside, filepath.Join(jail, "sub")} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(jail, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(jail, "sub"), filepath.Join(jail, "inside-link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../../outside", filepath.Join(jail, "sub", "relescape")); err != nil {
+		t.Fatal(err)
+	}
+
+	root := New(jail)
+
+	cases := []struct {
+		name    string
+		elem    []string
+		wantErr bool
+	}{
+		{"plain nested path", []string{"sub", "file.txt"}, false},
+		{"lexical escape via dotdot", []string{"..", "outside"}, true},
+		{"symlink escape", []string{"escape", "file.txt"}, true},
+		{"symlink staying inside jail", []string{"inside-link", "file.txt"}, false},
+		{"nested symlink escape", []string{"sub", "relescape", "file.txt"}, true},
+		{"not yet existing component", []string{"sub", "does-not-exist-yet"}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := root.SafeJoin(tc.elem...)
+			var escErr *ErrPathEscape
+			if tc.wantErr {
+				if !errors.As(err, &escErr) {
+					t.Fatalf("SafeJoin(%v) = %v, want ErrPathEscape", tc.elem, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SafeJoin(%v) = %v, want no error", tc.elem, err)
+			}
+		})
+	}
+}
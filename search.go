@@ -0,0 +1,63 @@
+package paths
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// FileContainsBytes reports whether the file contains sub anywhere in its
+// content.
+func (p *Path) FileContainsBytes(sub []byte) (bool, error) {
+	return p.FileContainsAnyBytes([][]byte{sub})
+}
+
+// FileContainsAnyBytes reports whether the file contains any of subs
+// anywhere in its content. The file is streamed through a bounded buffer
+// rather than read in full, so it works on files much larger than
+// available memory.
+func (p *Path) FileContainsAnyBytes(subs [][]byte) (bool, error) {
+	maxLen := 0
+	for _, sub := range subs {
+		if len(sub) > maxLen {
+			maxLen = len(sub)
+		}
+	}
+	if maxLen == 0 {
+		return false, nil
+	}
+
+	in, err := p.fs.Open(p.path)
+	if err != nil {
+		return false, err
+	}
+	defer in.Close()
+
+	const chunkSize = 64 * 1024
+	overlap := maxLen - 1
+
+	reader := bufio.NewReaderSize(in, chunkSize)
+	chunk := make([]byte, chunkSize)
+	window := make([]byte, 0, chunkSize+overlap)
+
+	for {
+		n, readErr := reader.Read(chunk)
+		if n > 0 {
+			window = append(window, chunk[:n]...)
+			for _, sub := range subs {
+				if bytes.Contains(window, sub) {
+					return true, nil
+				}
+			}
+			if len(window) > overlap {
+				window = window[len(window)-overlap:]
+			}
+		}
+		if readErr == io.EOF {
+			return false, nil
+		}
+		if readErr != nil {
+			return false, readErr
+		}
+	}
+}
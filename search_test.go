@@ -0,0 +1,93 @@
+package paths
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestFileContainsAnyBytes(t *testing.T) {
+	const chunkSize = 64 * 1024
+
+	write := func(t *testing.T, data []byte) *Path {
+		f, err := os.CreateTemp("", "search")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if _, err := f.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { os.Remove(f.Name()) })
+		return New(f.Name())
+	}
+
+	cases := []struct {
+		name string
+		subs [][]byte
+		want bool
+		data func() []byte
+	}{
+		{
+			name: "match wholly inside one chunk",
+			subs: [][]byte{[]byte("needle")},
+			want: true,
+			data: func() []byte {
+				return append(bytes.Repeat([]byte{'a'}, 100), []byte("needle")...)
+			},
+		},
+		{
+			name: "match straddling the chunk boundary",
+			subs: [][]byte{[]byte("BOUNDARY-MARKER")},
+			want: true,
+			data: func() []byte {
+				marker := []byte("BOUNDARY-MARKER")
+				// Place the marker so it starts a few bytes before the
+				// chunk boundary and ends a few bytes after it.
+				start := chunkSize - 5
+				buf := bytes.Repeat([]byte{'x'}, start)
+				buf = append(buf, marker...)
+				buf = append(buf, bytes.Repeat([]byte{'y'}, chunkSize)...)
+				return buf
+			},
+		},
+		{
+			name: "absent needle in multi-chunk haystack",
+			subs: [][]byte{[]byte("nope-not-here")},
+			want: false,
+			data: func() []byte {
+				return bytes.Repeat([]byte{'z'}, 3*chunkSize)
+			},
+		},
+		{
+			name: "one of several needles matches near the end",
+			subs: [][]byte{[]byte("absent-one"), []byte("tail-needle")},
+			want: true,
+			data: func() []byte {
+				buf := bytes.Repeat([]byte{'q'}, 2*chunkSize)
+				return append(buf, []byte("tail-needle")...)
+			},
+		},
+		{
+			name: "empty file",
+			subs: [][]byte{[]byte("anything")},
+			want: false,
+			data: func() []byte {
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := write(t, tc.data())
+			got, err := p.FileContainsAnyBytes(tc.subs)
+			if err != nil {
+				t.Fatalf("FileContainsAnyBytes: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("FileContainsAnyBytes() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
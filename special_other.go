@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly && !solaris
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd,!dragonfly,!solaris
+
+package paths
+
+import (
+	"fmt"
+	"os"
+)
+
+// copySpecialTo is not supported on this platform.
+func (p *Path) copySpecialTo(dst *Path, info os.FileInfo) error {
+	return fmt.Errorf("paths: copying special file %s is not supported on this platform", p.path)
+}
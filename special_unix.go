@@ -0,0 +1,30 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly || solaris
+// +build linux darwin freebsd netbsd openbsd dragonfly solaris
+
+package paths
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// copySpecialTo recreates device, FIFO and socket nodes at dst using
+// mknod, preserving their mode bits. Mknod operates directly on the local
+// filesystem, so dst must be backed by OSFS.
+func (p *Path) copySpecialTo(dst *Path, info os.FileInfo) error {
+	if _, ok := dst.fs.(OSFS); !ok {
+		return fmt.Errorf("paths: copying special file %s requires an OSFS destination", p.path)
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("paths: copying special file %s: no syscall.Stat_t available", p.path)
+	}
+	// st.Mode already carries the C S_IFIFO/S_IFCHR/S_IFBLK type bits Mknod
+	// expects; info.Mode() is Go's os.FileMode encoding and doesn't.
+	if err := syscall.Mknod(dst.path, uint32(st.Mode), int(st.Rdev)); err != nil {
+		return err
+	}
+	// Mknod applies the process umask, so set the permission bits again.
+	return os.Chmod(dst.path, info.Mode().Perm())
+}
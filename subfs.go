@@ -0,0 +1,101 @@
+package paths
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// errSubFSReadOnly is returned by every mutating operation on a SubFS.
+var errSubFSReadOnly = errors.New("paths: sub filesystem is read-only")
+
+// subFS chroots an existing FS under root and rejects any name that
+// lexically escapes it with "..".
+type subFS struct {
+	base FS
+	root string
+}
+
+// SubFS returns a read-only FS that chroots operations of the underlying
+// filesystem under root, rejecting any path that escapes it with "..".
+// It's meant for handing untrusted, relative paths to code that otherwise
+// has no business seeing outside of root, e.g. serving files under a
+// per-user directory.
+func SubFS(root *Path) FS {
+	return &subFS{base: root.fs, root: root.path}
+}
+
+func (s *subFS) resolve(name string) (string, error) {
+	full := filepath.Join(s.root, name)
+
+	rel, err := filepath.Rel(s.root, full)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("paths: %q escapes sub filesystem root %q", name, s.root)
+	}
+
+	return full, nil
+}
+
+func (s *subFS) Stat(name string) (os.FileInfo, error) {
+	full, err := s.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.base.Stat(full)
+}
+
+func (s *subFS) Lstat(name string) (os.FileInfo, error) {
+	full, err := s.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.base.Lstat(full)
+}
+
+func (s *subFS) Open(name string) (io.ReadCloser, error) {
+	full, err := s.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.base.Open(full)
+}
+
+func (s *subFS) ReadDir(name string) ([]os.FileInfo, error) {
+	full, err := s.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return s.base.ReadDir(full)
+}
+
+func (s *subFS) Readlink(name string) (string, error) {
+	full, err := s.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	return s.base.Readlink(full)
+}
+
+func (s *subFS) Create(name string) (io.WriteCloser, error)        { return nil, errSubFSReadOnly }
+func (s *subFS) MkdirAll(name string, perm os.FileMode) error      { return errSubFSReadOnly }
+func (s *subFS) Remove(name string) error                          { return errSubFSReadOnly }
+func (s *subFS) Chtimes(name string, atime, mtime time.Time) error { return errSubFSReadOnly }
+func (s *subFS) Chmod(name string, mode os.FileMode) error         { return errSubFSReadOnly }
+func (s *subFS) Symlink(oldname, newname string) error             { return errSubFSReadOnly }
+func (s *subFS) Link(oldname, newname string) error                { return errSubFSReadOnly }
+func (s *subFS) Rename(oldname, newname string) error              { return errSubFSReadOnly }
+
+func (s *subFS) SyncDir(name string) error {
+	full, err := s.resolve(name)
+	if err != nil {
+		return err
+	}
+	return s.base.SyncDir(full)
+}
@@ -0,0 +1,117 @@
+package paths
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// UsageTracker keeps a cached disk usage figure for a directory tree,
+// refreshed either on demand via Refresh or automatically in the
+// background when constructed with a positive refresh interval.
+type UsageTracker struct {
+	root *Path
+
+	// Limit, if greater than zero, is the quota HasSpaceFor checks
+	// against. It's left at zero (unlimited) unless the caller sets it.
+	Limit int64
+
+	mu     sync.Mutex
+	cached int64
+	done   chan struct{} // non-nil while a refresh is in flight
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewUsageTracker returns a tracker for root. If refreshInterval is
+// greater than zero, a background goroutine calls Refresh on that cadence
+// until Close is called; otherwise the cache is only ever updated by
+// explicit calls to Refresh.
+func NewUsageTracker(root *Path, refreshInterval time.Duration) *UsageTracker {
+	t := &UsageTracker{root: root, stop: make(chan struct{})}
+
+	if refreshInterval > 0 {
+		go t.refreshLoop(refreshInterval)
+	}
+
+	return t
+}
+
+func (t *UsageTracker) refreshLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.Refresh(context.Background())
+		case <-t.stop:
+			return
+		}
+	}
+}
+
+// Close stops the background refresh goroutine started by
+// NewUsageTracker. It's a no-op if no refresh interval was configured.
+func (t *UsageTracker) Close() error {
+	t.stopOnce.Do(func() { close(t.stop) })
+	return nil
+}
+
+// Cached returns the last usage figure computed by Refresh, without
+// blocking on any refresh that might currently be in flight. It's zero
+// until the first successful Refresh.
+func (t *UsageTracker) Cached() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cached
+}
+
+// Refresh recomputes disk usage by walking root and updates the cached
+// value. Concurrent calls to Refresh coalesce onto a single walk: callers
+// that arrive while one is already in flight wait for it to finish (or
+// for ctx to be done) and then return the resulting cached value, rather
+// than starting a redundant walk of their own.
+func (t *UsageTracker) Refresh(ctx context.Context) (int64, error) {
+	t.mu.Lock()
+	if t.done != nil {
+		done := t.done
+		t.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+		return t.Cached(), nil
+	}
+
+	done := make(chan struct{})
+	t.done = done
+	t.mu.Unlock()
+
+	usage, err := t.root.DiskUsage()
+
+	t.mu.Lock()
+	if err == nil {
+		t.cached = usage
+	}
+	t.done = nil
+	t.mu.Unlock()
+	close(done)
+
+	if err != nil {
+		return 0, err
+	}
+	return usage, nil
+}
+
+// HasSpaceFor reports whether adding n bytes would stay within Limit. It
+// always reports true when Limit is zero or negative (unlimited).
+func (t *UsageTracker) HasSpaceFor(n int64) bool {
+	if t.Limit <= 0 {
+		return true
+	}
+	return t.Cached()+n <= t.Limit
+}
@@ -0,0 +1,113 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WalkFunc is the type of the function called for each file or directory
+// visited by Walk or WalkFollowSymlinks. p is the path being visited, info
+// is its FileInfo and err is any error encountered while stat-ing p,
+// resolving it as a symlink or reading its entries. info is nil when p
+// couldn't be stat-ed or its entries couldn't be read; on a symlink
+// resolution error it is the symlink's own FileInfo. If the function
+// returns filepath.SkipDir:
+//   - when p is a directory, Walk doesn't descend into it, but continues
+//     on with the rest of the tree as if it had never been visited;
+//   - when p is not a directory, Walk skips the remaining, not yet
+//     visited entries of p's containing directory.
+type WalkFunc func(p *Path, info os.FileInfo, err error) error
+
+// Walk walks the file tree rooted at the current Path, calling fn for the
+// root and every file or directory beneath it. Symlinks are reported to fn
+// but never followed.
+func (p *Path) Walk(fn WalkFunc) error {
+	info, err := p.Stat()
+	if err := p.walk(info, err, false, nil, fn); err != filepath.SkipDir {
+		return err
+	}
+	return nil
+}
+
+// WalkFollowSymlinks is like Walk but also descends into symlinks that
+// point to directories. The absolute path a symlink resolves to is
+// remembered, so a symlink cycle is detected and pruned rather than
+// walked forever.
+func (p *Path) WalkFollowSymlinks(fn WalkFunc) error {
+	info, err := p.Stat()
+	if err := p.walk(info, err, true, map[string]struct{}{}, fn); err != filepath.SkipDir {
+		return err
+	}
+	return nil
+}
+
+// reportDirErr calls fn with err and interprets the result the way an
+// error encountered while trying to descend into p (resolving a symlink,
+// or reading a directory's entries) should be handled: filepath.SkipDir
+// means "give up on this subtree without failing the walk", anything
+// else is returned as-is to the caller.
+func reportDirErr(fn WalkFunc, p *Path, info os.FileInfo, err error) error {
+	if cbErr := fn(p, info, err); cbErr != filepath.SkipDir {
+		return cbErr
+	}
+	return nil
+}
+
+func (p *Path) walk(info os.FileInfo, statErr error, followSymlinks bool, visited map[string]struct{}, fn WalkFunc) error {
+	err := fn(p, info, statErr)
+	if statErr != nil {
+		return err
+	}
+
+	isDir := info.IsDir()
+	if err != nil {
+		if err == filepath.SkipDir && isDir {
+			return nil
+		}
+		return err
+	}
+
+	dir := p
+	if followSymlinks && info.Mode()&os.ModeSymlink != 0 {
+		resolved := p.Clone()
+		if err := resolved.FollowSymLink(); err != nil {
+			return reportDirErr(fn, p, info, err)
+		}
+		rinfo, err := resolved.Stat()
+		if err != nil {
+			return reportDirErr(fn, p, info, err)
+		}
+		if !rinfo.IsDir() {
+			return nil
+		}
+		abs, err := filepath.Abs(resolved.path)
+		if err != nil {
+			return reportDirErr(fn, p, info, err)
+		}
+		if _, seen := visited[abs]; seen {
+			return nil
+		}
+		visited[abs] = struct{}{}
+		dir, info, isDir = resolved, rinfo, true
+	}
+
+	if !isDir {
+		return nil
+	}
+
+	entries, err := dir.ReadDir()
+	if err != nil {
+		return reportDirErr(fn, dir, nil, err)
+	}
+
+	for _, entry := range entries {
+		err := entry.walk(entry.cachedFileInfo, nil, followSymlinks, visited, fn)
+		if err == filepath.SkipDir {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}